@@ -7,8 +7,10 @@ import (
 
 	"github.com/gorilla/mux"
 	"github.com/joho/godotenv"
+	"github.com/yourusername/go-user-api/internal/auth"
 	"github.com/yourusername/go-user-api/internal/database"
 	"github.com/yourusername/go-user-api/internal/handlers"
+	"github.com/yourusername/go-user-api/internal/mailer"
 	"github.com/yourusername/go-user-api/internal/middleware"
 )
 
@@ -35,17 +37,42 @@ func main() {
 	r.Use(middleware.CORS)
 
 	// Public routes
-	r.HandleFunc("/api/auth/register", handlers.Register(db)).Methods("POST")
-	r.HandleFunc("/api/auth/login", handlers.Login(db)).Methods("POST")
+	r.Handle("/api/auth/register", handlers.Register(db)).Methods("POST")
+	r.Handle("/api/auth/login", handlers.Login(db)).Methods("POST")
+	r.HandleFunc("/api/auth/refresh", handlers.Refresh(db)).Methods("POST")
+	r.HandleFunc("/api/auth/logout", handlers.Logout(db)).Methods("POST")
+
+	mailService := mailer.FromEnv()
+	r.HandleFunc("/api/auth/password/forgot", handlers.ForgotPassword(db, mailService)).Methods("POST")
+	r.HandleFunc("/api/auth/password/reset", handlers.ResetPassword(db)).Methods("POST")
+
+	// OAuth2/OIDC login routes
+	oauthProviders := auth.ProvidersFromEnv()
+	r.HandleFunc("/api/auth/oauth/{provider}/login", handlers.OAuthLogin(oauthProviders)).Methods("GET")
+	r.HandleFunc("/api/auth/oauth/{provider}/callback", handlers.OAuthCallback(db, oauthProviders)).Methods("GET")
 
 	// Protected routes
 	api := r.PathPrefix("/api/users").Subrouter()
 	api.Use(middleware.Auth)
-	api.HandleFunc("", handlers.GetUsers(db)).Methods("GET")
-	api.HandleFunc("/me", handlers.GetCurrentUser(db)).Methods("GET")
-	api.HandleFunc("/{id}", handlers.GetUser(db)).Methods("GET")
-	api.HandleFunc("/{id}", handlers.UpdateUser(db)).Methods("PUT")
-	api.HandleFunc("/{id}", handlers.DeleteUser(db)).Methods("DELETE")
+	api.Handle("", middleware.RequireRole("admin")(handlers.GetUsers(db))).Methods("GET")
+	api.Handle("/me", handlers.GetCurrentUser(db)).Methods("GET")
+	api.Handle("/{id}", handlers.GetUser(db)).Methods("GET")
+	api.Handle("/{id}", handlers.UpdateUser(db)).Methods("PUT")
+	api.Handle("/{id}", handlers.DeleteUser(db)).Methods("DELETE")
+	api.Handle("/{id}/scopes", middleware.RequireRole("admin")(handlers.UpdateUserScopes(db))).Methods("PATCH")
+	api.Handle("/{id}/comments", handlers.ListCommentsByUser(db)).Methods("GET")
+
+	// Admin bootstrap (promotes the first caller to admin from empty state)
+	r.Handle("/api/auth/bootstrap-admin", middleware.Auth(handlers.BootstrapAdmin(db))).Methods("POST")
+
+	// Comments
+	comments := r.PathPrefix("/api/comments").Subrouter()
+	comments.Use(middleware.Auth)
+	comments.Handle("", handlers.ListComments(db)).Methods("GET")
+	comments.Handle("", handlers.CreateComment(db)).Methods("POST")
+	comments.Handle("/{id}", handlers.GetComment(db)).Methods("GET")
+	comments.Handle("/{id}", handlers.UpdateComment(db)).Methods("PUT")
+	comments.Handle("/{id}", handlers.DeleteComment(db)).Methods("DELETE")
 
 	// Health check
 	r.HandleFunc("/health", func(w http.ResponseWriter, r *http.Request) {
@@ -0,0 +1,142 @@
+package auth
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"golang.org/x/oauth2"
+	githuboauth "golang.org/x/oauth2/github"
+)
+
+const githubUserAPIURL = "https://api.github.com/user"
+const githubUserEmailsAPIURL = "https://api.github.com/user/emails"
+
+// GitHubProvider implements OAuthProvider for GitHub's OAuth2 endpoints.
+type GitHubProvider struct {
+	config *oauth2.Config
+}
+
+// NewGitHubProvider builds a GitHubProvider from client credentials,
+// typically sourced from GITHUB_CLIENT_ID / GITHUB_CLIENT_SECRET /
+// GITHUB_REDIRECT_URL environment variables.
+func NewGitHubProvider(clientID, clientSecret, redirectURL string) *GitHubProvider {
+	return &GitHubProvider{
+		config: &oauth2.Config{
+			ClientID:     clientID,
+			ClientSecret: clientSecret,
+			RedirectURL:  redirectURL,
+			Endpoint:     githuboauth.Endpoint,
+			Scopes:       []string{"read:user", "user:email"},
+		},
+	}
+}
+
+func (p *GitHubProvider) Name() string { return "github" }
+
+func (p *GitHubProvider) AuthURL(state string) string {
+	return p.config.AuthCodeURL(state)
+}
+
+func (p *GitHubProvider) Exchange(ctx context.Context, code string) (*Token, error) {
+	tok, err := p.config.Exchange(ctx, code)
+	if err != nil {
+		return nil, err
+	}
+	return &Token{AccessToken: tok.AccessToken}, nil
+}
+
+func (p *GitHubProvider) UserInfo(ctx context.Context, token *Token) (*UserInfo, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, githubUserAPIURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Authorization", "Bearer "+token.AccessToken)
+	req.Header.Set("Accept", "application/vnd.github+json")
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("github userinfo: unexpected status %d", resp.StatusCode)
+	}
+
+	var body struct {
+		ID    int    `json:"id"`
+		Email string `json:"email"`
+		Name  string `json:"name"`
+		Login string `json:"login"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		return nil, err
+	}
+
+	name := body.Name
+	if name == "" {
+		name = body.Login
+	}
+
+	email := body.Email
+	if email == "" {
+		// GitHub only returns "email" on /user when the user has made an
+		// address public, which most users don't. Fall back to
+		// /user/emails, preferring the primary, verified address
+		// (requires the user:email scope, already requested above).
+		email, err = p.primaryVerifiedEmail(ctx, token)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return &UserInfo{Subject: strconv.Itoa(body.ID), Email: email, Name: name}, nil
+}
+
+// primaryVerifiedEmail fetches the authenticated user's email addresses via
+// GET /user/emails, since /user omits email unless the user has made one
+// public. It returns the primary, verified address if there is one, else
+// the first verified address, else an empty string with a nil error.
+func (p *GitHubProvider) primaryVerifiedEmail(ctx context.Context, token *Token) (string, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, githubUserEmailsAPIURL, nil)
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Authorization", "Bearer "+token.AccessToken)
+	req.Header.Set("Accept", "application/vnd.github+json")
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("github user emails: unexpected status %d", resp.StatusCode)
+	}
+
+	var emails []struct {
+		Email    string `json:"email"`
+		Primary  bool   `json:"primary"`
+		Verified bool   `json:"verified"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&emails); err != nil {
+		return "", err
+	}
+
+	for _, e := range emails {
+		if e.Primary && e.Verified {
+			return e.Email, nil
+		}
+	}
+	for _, e := range emails {
+		if e.Verified {
+			return e.Email, nil
+		}
+	}
+
+	return "", nil
+}
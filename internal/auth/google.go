@@ -0,0 +1,77 @@
+package auth
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"golang.org/x/oauth2"
+	"golang.org/x/oauth2/google"
+)
+
+const googleUserInfoURL = "https://www.googleapis.com/oauth2/v3/userinfo"
+
+// GoogleProvider implements OAuthProvider for Google's OIDC-compatible
+// OAuth2 endpoints.
+type GoogleProvider struct {
+	config *oauth2.Config
+}
+
+// NewGoogleProvider builds a GoogleProvider from client credentials,
+// typically sourced from GOOGLE_CLIENT_ID / GOOGLE_CLIENT_SECRET /
+// GOOGLE_REDIRECT_URL environment variables.
+func NewGoogleProvider(clientID, clientSecret, redirectURL string) *GoogleProvider {
+	return &GoogleProvider{
+		config: &oauth2.Config{
+			ClientID:     clientID,
+			ClientSecret: clientSecret,
+			RedirectURL:  redirectURL,
+			Endpoint:     google.Endpoint,
+			Scopes:       []string{"openid", "email", "profile"},
+		},
+	}
+}
+
+func (p *GoogleProvider) Name() string { return "google" }
+
+func (p *GoogleProvider) AuthURL(state string) string {
+	return p.config.AuthCodeURL(state, oauth2.AccessTypeOnline)
+}
+
+func (p *GoogleProvider) Exchange(ctx context.Context, code string) (*Token, error) {
+	tok, err := p.config.Exchange(ctx, code)
+	if err != nil {
+		return nil, err
+	}
+	return &Token{AccessToken: tok.AccessToken}, nil
+}
+
+func (p *GoogleProvider) UserInfo(ctx context.Context, token *Token) (*UserInfo, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, googleUserInfoURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Authorization", "Bearer "+token.AccessToken)
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("google userinfo: unexpected status %d", resp.StatusCode)
+	}
+
+	var body struct {
+		Sub   string `json:"sub"`
+		Email string `json:"email"`
+		Name  string `json:"name"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		return nil, err
+	}
+
+	return &UserInfo{Subject: body.Sub, Email: body.Email, Name: body.Name}, nil
+}
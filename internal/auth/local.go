@@ -0,0 +1,42 @@
+package auth
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/yourusername/go-user-api/internal/utils"
+)
+
+// ErrInvalidCredentials is returned by AttemptLogin when the email is
+// unknown or the password does not match.
+var ErrInvalidCredentials = errors.New("invalid email or password")
+
+// DBLoginProvider implements LoginProvider against the users table,
+// checking the submitted password against the stored bcrypt hash.
+type DBLoginProvider struct {
+	DB *sql.DB
+}
+
+// NewDBLoginProvider builds a DBLoginProvider backed by db.
+func NewDBLoginProvider(db *sql.DB) *DBLoginProvider {
+	return &DBLoginProvider{DB: db}
+}
+
+func (p *DBLoginProvider) AttemptLogin(ctx context.Context, email, password string) (int, error) {
+	var id int
+	var passwordHash string
+	err := p.DB.QueryRowContext(ctx, `SELECT id, password_hash FROM users WHERE email = $1`, email).Scan(&id, &passwordHash)
+	if err == sql.ErrNoRows {
+		return 0, ErrInvalidCredentials
+	}
+	if err != nil {
+		return 0, err
+	}
+
+	if !utils.CheckPassword(passwordHash, password) {
+		return 0, ErrInvalidCredentials
+	}
+
+	return id, nil
+}
@@ -0,0 +1,45 @@
+package auth
+
+import (
+	"context"
+)
+
+// LoginProvider authenticates a user against a set of local credentials,
+// e.g. an email/password pair stored in the users table.
+type LoginProvider interface {
+	AttemptLogin(ctx context.Context, email, password string) (userID int, err error)
+}
+
+// UserInfo is the normalized profile returned by an OAuthProvider after a
+// successful token exchange.
+type UserInfo struct {
+	// Subject is the provider's stable, unique identifier for the user
+	// (Google's "sub", GitHub's numeric user ID as a string, etc).
+	Subject string
+	Email   string
+	Name    string
+}
+
+// OAuthProvider drives the authorization-code flow for a single third-party
+// identity provider (Google, GitHub, a generic OIDC issuer, ...).
+type OAuthProvider interface {
+	// Name identifies the provider, e.g. "google", matching the
+	// {provider} path segment used in the oauth routes.
+	Name() string
+	// AuthURL returns the URL the user should be redirected to in order to
+	// start the flow. state is echoed back on the callback and must be
+	// verified by the caller to prevent CSRF.
+	AuthURL(state string) string
+	// Exchange trades an authorization code from the callback request for
+	// an access token.
+	Exchange(ctx context.Context, code string) (*Token, error)
+	// UserInfo fetches the authenticated user's profile using the token
+	// returned by Exchange.
+	UserInfo(ctx context.Context, token *Token) (*UserInfo, error)
+}
+
+// Token is a minimal, provider-agnostic view of an OAuth2 token. Concrete
+// providers wrap *oauth2.Token to satisfy this.
+type Token struct {
+	AccessToken string
+}
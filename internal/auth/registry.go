@@ -0,0 +1,40 @@
+package auth
+
+import "os"
+
+// ProvidersFromEnv builds the set of configured OAuthProviders, keyed by
+// name, from environment variables. A provider is only included if its
+// client ID is set, so operators can enable just the providers they need.
+func ProvidersFromEnv() map[string]OAuthProvider {
+	providers := map[string]OAuthProvider{}
+
+	if clientID := os.Getenv("GOOGLE_CLIENT_ID"); clientID != "" {
+		providers["google"] = NewGoogleProvider(
+			clientID,
+			os.Getenv("GOOGLE_CLIENT_SECRET"),
+			os.Getenv("GOOGLE_REDIRECT_URL"),
+		)
+	}
+
+	if clientID := os.Getenv("GITHUB_CLIENT_ID"); clientID != "" {
+		providers["github"] = NewGitHubProvider(
+			clientID,
+			os.Getenv("GITHUB_CLIENT_SECRET"),
+			os.Getenv("GITHUB_REDIRECT_URL"),
+		)
+	}
+
+	if clientID := os.Getenv("OIDC_CLIENT_ID"); clientID != "" {
+		providers["oidc"] = NewOIDCProvider(
+			"oidc",
+			clientID,
+			os.Getenv("OIDC_CLIENT_SECRET"),
+			os.Getenv("OIDC_REDIRECT_URL"),
+			os.Getenv("OIDC_AUTH_URL"),
+			os.Getenv("OIDC_TOKEN_URL"),
+			os.Getenv("OIDC_USERINFO_URL"),
+		)
+	}
+
+	return providers
+}
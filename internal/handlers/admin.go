@@ -0,0 +1,150 @@
+package handlers
+
+import (
+	"database/sql"
+	"encoding/json"
+	"net/http"
+	"os"
+	"strconv"
+
+	"github.com/gorilla/mux"
+	"github.com/lib/pq"
+	"github.com/yourusername/go-user-api/internal/models"
+)
+
+// UpdateUserScopes sets a user's scopes. Gated to admins by
+// middleware.RequireRole("admin") at the route level.
+func UpdateUserScopes(db *sql.DB) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		vars := mux.Vars(r)
+		id, err := strconv.Atoi(vars["id"])
+		if err != nil {
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusBadRequest)
+			json.NewEncoder(w).Encode(ErrorResponse{Error: "Invalid user ID"})
+			return
+		}
+
+		var req models.UpdateScopesRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusBadRequest)
+			json.NewEncoder(w).Encode(ErrorResponse{Error: "Invalid request body"})
+			return
+		}
+
+		var user models.User
+		query := `
+			UPDATE users
+			SET scopes = $1, updated_at = NOW()
+			WHERE id = $2
+			RETURNING id, email, username, auth_type, role, scopes, first_name, last_name, created_at, updated_at
+		`
+		err = db.QueryRow(query, pq.Array(req.Scopes), id).Scan(
+			&user.ID,
+			&user.Email,
+			&user.Username,
+			&user.AuthType,
+			&user.Role,
+			pq.Array(&user.Scopes),
+			&user.FirstName,
+			&user.LastName,
+			&user.CreatedAt,
+			&user.UpdatedAt,
+		)
+
+		if err == sql.ErrNoRows {
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusNotFound)
+			json.NewEncoder(w).Encode(ErrorResponse{Error: "User not found"})
+			return
+		} else if err != nil {
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusInternalServerError)
+			json.NewEncoder(w).Encode(ErrorResponse{Error: "Failed to update scopes"})
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(user)
+	}
+}
+
+// BootstrapAdminRequest is the body of POST /api/auth/bootstrap-admin.
+type BootstrapAdminRequest struct {
+	Token string `json:"token"`
+}
+
+// BootstrapAdmin promotes the calling user to admin if they present the
+// ADMIN_BOOTSTRAP_TOKEN and no admin exists yet. This is the only way to
+// reach admin status from an empty database.
+func BootstrapAdmin(db *sql.DB) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		bootstrapToken := os.Getenv("ADMIN_BOOTSTRAP_TOKEN")
+		if bootstrapToken == "" {
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusNotFound)
+			json.NewEncoder(w).Encode(ErrorResponse{Error: "Admin bootstrap is disabled"})
+			return
+		}
+
+		userID, ok := r.Context().Value("userID").(int)
+		if !ok {
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusUnauthorized)
+			json.NewEncoder(w).Encode(ErrorResponse{Error: "Unauthorized"})
+			return
+		}
+
+		var req BootstrapAdminRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.Token != bootstrapToken {
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusForbidden)
+			json.NewEncoder(w).Encode(ErrorResponse{Error: "Invalid bootstrap token"})
+			return
+		}
+
+		var adminCount int
+		if err := db.QueryRow(`SELECT COUNT(*) FROM users WHERE role = 'admin'`).Scan(&adminCount); err != nil {
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusInternalServerError)
+			json.NewEncoder(w).Encode(ErrorResponse{Error: "Database error"})
+			return
+		}
+		if adminCount > 0 {
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusConflict)
+			json.NewEncoder(w).Encode(ErrorResponse{Error: "An admin already exists"})
+			return
+		}
+
+		var user models.User
+		query := `
+			UPDATE users
+			SET role = 'admin', updated_at = NOW()
+			WHERE id = $1
+			RETURNING id, email, username, auth_type, role, scopes, first_name, last_name, created_at, updated_at
+		`
+		err := db.QueryRow(query, userID).Scan(
+			&user.ID,
+			&user.Email,
+			&user.Username,
+			&user.AuthType,
+			&user.Role,
+			pq.Array(&user.Scopes),
+			&user.FirstName,
+			&user.LastName,
+			&user.CreatedAt,
+			&user.UpdatedAt,
+		)
+		if err != nil {
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusInternalServerError)
+			json.NewEncoder(w).Encode(ErrorResponse{Error: "Failed to promote user"})
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(user)
+	}
+}
@@ -2,9 +2,10 @@ package handlers
 
 import (
 	"database/sql"
-	"encoding/json"
 	"net/http"
 
+	"github.com/lib/pq"
+	"github.com/yourusername/go-user-api/internal/auth"
 	"github.com/yourusername/go-user-api/internal/models"
 	"github.com/yourusername/go-user-api/internal/utils"
 )
@@ -14,159 +15,118 @@ type ErrorResponse struct {
 }
 
 // Register creates a new user account
-func Register(db *sql.DB) http.HandlerFunc {
-	return func(w http.ResponseWriter, r *http.Request) {
-		var req models.RegisterRequest
-
-		// Parse request body
-		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-			w.Header().Set("Content-Type", "application/json")
-			w.WriteHeader(http.StatusBadRequest)
-			json.NewEncoder(w).Encode(ErrorResponse{Error: "Invalid request body"})
-			return
-		}
-
-		// Validate input
-		if err := req.Validate(); err != nil {
-			w.Header().Set("Content-Type", "application/json")
-			w.WriteHeader(http.StatusBadRequest)
-			json.NewEncoder(w).Encode(ErrorResponse{Error: err.Error()})
-			return
-		}
-
-		// Hash password
-		hashedPassword, err := utils.HashPassword(req.Password)
-		if err != nil {
-			w.Header().Set("Content-Type", "application/json")
-			w.WriteHeader(http.StatusInternalServerError)
-			json.NewEncoder(w).Encode(ErrorResponse{Error: "Failed to process password"})
-			return
-		}
-
-		// Insert user into database
-		var user models.User
-		query := `
-			INSERT INTO users (email, username, password_hash, first_name, last_name)
-			VALUES ($1, $2, $3, $4, $5)
-			RETURNING id, email, username, first_name, last_name, created_at, updated_at
-		`
-		err = db.QueryRow(
-			query,
-			req.Email,
-			req.Username,
-			hashedPassword,
-			req.FirstName,
-			req.LastName,
-		).Scan(
-			&user.ID,
-			&user.Email,
-			&user.Username,
-			&user.FirstName,
-			&user.LastName,
-			&user.CreatedAt,
-			&user.UpdatedAt,
-		)
-
-		if err != nil {
-			// Check for duplicate email/username
-			w.Header().Set("Content-Type", "application/json")
-			w.WriteHeader(http.StatusConflict)
-			json.NewEncoder(w).Encode(ErrorResponse{Error: "Email or username already exists"})
-			return
-		}
-
-		// Generate JWT token
-		token, err := utils.GenerateToken(user.ID)
-		if err != nil {
-			w.Header().Set("Content-Type", "application/json")
-			w.WriteHeader(http.StatusInternalServerError)
-			json.NewEncoder(w).Encode(ErrorResponse{Error: "Failed to generate token"})
-			return
-		}
-
-		// Return success response
-		w.Header().Set("Content-Type", "application/json")
-		w.WriteHeader(http.StatusCreated)
-		json.NewEncoder(w).Encode(models.LoginResponse{
-			Token: token,
-			User:  user,
-		})
+func Register(db *sql.DB) http.Handler {
+	return &JSONHandler{
+		DB:    db,
+		Input: func() interface{} { return &models.RegisterRequest{} },
+		Process: func(r *http.Request, in interface{}, db *sql.DB) (JSONResult, error) {
+			req := in.(*models.RegisterRequest)
+
+			hashedPassword, err := utils.HashPassword(req.Password)
+			if err != nil {
+				return JSONResult{}, Internal("Failed to process password")
+			}
+
+			var user models.User
+			query := `
+				INSERT INTO users (email, username, password_hash, first_name, last_name)
+				VALUES ($1, $2, $3, $4, $5)
+				RETURNING id, email, username, auth_type, role, scopes, first_name, last_name, created_at, updated_at
+			`
+			err = db.QueryRow(
+				query,
+				req.Email,
+				req.Username,
+				hashedPassword,
+				req.FirstName,
+				req.LastName,
+			).Scan(
+				&user.ID,
+				&user.Email,
+				&user.Username,
+				&user.AuthType,
+				&user.Role,
+				pq.Array(&user.Scopes),
+				&user.FirstName,
+				&user.LastName,
+				&user.CreatedAt,
+				&user.UpdatedAt,
+			)
+			if err != nil {
+				// Check for duplicate email/username
+				return JSONResult{}, Conflict("Email or username already exists")
+			}
+
+			accessToken, refreshToken, _, err := issueTokenPair(db, &user)
+			if err != nil {
+				return JSONResult{}, Internal("Failed to generate token")
+			}
+
+			return JSONResult{
+				Code: http.StatusCreated,
+				Result: models.LoginResponse{
+					AccessToken:  accessToken,
+					RefreshToken: refreshToken,
+					User:         user,
+				},
+			}, nil
+		},
 	}
 }
 
-// Login authenticates a user and returns a JWT token
-func Login(db *sql.DB) http.HandlerFunc {
-	return func(w http.ResponseWriter, r *http.Request) {
-		var req models.LoginRequest
-
-		// Parse request body
-		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-			w.Header().Set("Content-Type", "application/json")
-			w.WriteHeader(http.StatusBadRequest)
-			json.NewEncoder(w).Encode(ErrorResponse{Error: "Invalid request body"})
-			return
-		}
-
-		// Validate input
-		if req.Email == "" || req.Password == "" {
-			w.Header().Set("Content-Type", "application/json")
-			w.WriteHeader(http.StatusBadRequest)
-			json.NewEncoder(w).Encode(ErrorResponse{Error: "Email and password are required"})
-			return
-		}
-
-		// Find user by email
-		var user models.User
-		query := `
-			SELECT id, email, username, password_hash, first_name, last_name, created_at, updated_at
-			FROM users
-			WHERE email = $1
-		`
-		err := db.QueryRow(query, req.Email).Scan(
-			&user.ID,
-			&user.Email,
-			&user.Username,
-			&user.PasswordHash,
-			&user.FirstName,
-			&user.LastName,
-			&user.CreatedAt,
-			&user.UpdatedAt,
-		)
-
-		if err == sql.ErrNoRows {
-			w.Header().Set("Content-Type", "application/json")
-			w.WriteHeader(http.StatusUnauthorized)
-			json.NewEncoder(w).Encode(ErrorResponse{Error: "Invalid email or password"})
-			return
-		} else if err != nil {
-			w.Header().Set("Content-Type", "application/json")
-			w.WriteHeader(http.StatusInternalServerError)
-			json.NewEncoder(w).Encode(ErrorResponse{Error: "Database error"})
-			return
-		}
-
-		// Check password
-		if !utils.CheckPassword(user.PasswordHash, req.Password) {
-			w.Header().Set("Content-Type", "application/json")
-			w.WriteHeader(http.StatusUnauthorized)
-			json.NewEncoder(w).Encode(ErrorResponse{Error: "Invalid email or password"})
-			return
-		}
-
-		// Generate JWT token
-		token, err := utils.GenerateToken(user.ID)
-		if err != nil {
-			w.Header().Set("Content-Type", "application/json")
-			w.WriteHeader(http.StatusInternalServerError)
-			json.NewEncoder(w).Encode(ErrorResponse{Error: "Failed to generate token"})
-			return
-		}
-
-		// Return success response
-		w.Header().Set("Content-Type", "application/json")
-		json.NewEncoder(w).Encode(models.LoginResponse{
-			Token: token,
-			User:  user,
-		})
+// Login authenticates a user and returns an access+refresh token pair
+func Login(db *sql.DB) http.Handler {
+	loginProvider := auth.NewDBLoginProvider(db)
+
+	return &JSONHandler{
+		DB:    db,
+		Input: func() interface{} { return &models.LoginRequest{} },
+		Process: func(r *http.Request, in interface{}, db *sql.DB) (JSONResult, error) {
+			req := in.(*models.LoginRequest)
+
+			if req.Email == "" || req.Password == "" {
+				return JSONResult{}, BadRequest("Email and password are required")
+			}
+
+			userID, err := loginProvider.AttemptLogin(r.Context(), req.Email, req.Password)
+			if err == auth.ErrInvalidCredentials {
+				return JSONResult{}, Unauthorized("Invalid email or password")
+			} else if err != nil {
+				return JSONResult{}, Internal("Database error")
+			}
+
+			var user models.User
+			query := `
+				SELECT id, email, username, auth_type, role, scopes, first_name, last_name, created_at, updated_at
+				FROM users
+				WHERE id = $1
+			`
+			err = db.QueryRow(query, userID).Scan(
+				&user.ID,
+				&user.Email,
+				&user.Username,
+				&user.AuthType,
+				&user.Role,
+				pq.Array(&user.Scopes),
+				&user.FirstName,
+				&user.LastName,
+				&user.CreatedAt,
+				&user.UpdatedAt,
+			)
+			if err != nil {
+				return JSONResult{}, Internal("Database error")
+			}
+
+			accessToken, refreshToken, _, err := issueTokenPair(db, &user)
+			if err != nil {
+				return JSONResult{}, Internal("Failed to generate token")
+			}
+
+			return JSONResult{Result: models.LoginResponse{
+				AccessToken:  accessToken,
+				RefreshToken: refreshToken,
+				User:         user,
+			}}, nil
+		},
 	}
 }
@@ -0,0 +1,271 @@
+package handlers
+
+import (
+	"database/sql"
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/mux"
+	"github.com/yourusername/go-user-api/internal/models"
+)
+
+const defaultCommentPageSize = 20
+const maxCommentPageSize = 100
+
+// CreateComment creates a new comment (optionally a reply via parent_id)
+// authored by the authenticated user.
+func CreateComment(db *sql.DB) http.Handler {
+	return &JSONHandler{
+		DB:    db,
+		Input: func() interface{} { return &models.CreateCommentRequest{} },
+		Process: func(r *http.Request, in interface{}, db *sql.DB) (JSONResult, error) {
+			req := in.(*models.CreateCommentRequest)
+
+			userID, ok := r.Context().Value("userID").(int)
+			if !ok {
+				return JSONResult{}, Unauthorized("Unauthorized")
+			}
+
+			var comment models.Comment
+			query := `
+				INSERT INTO comments (user_id, parent_id, body)
+				VALUES ($1, $2, $3)
+				RETURNING id, user_id, parent_id, body, created_at, updated_at
+			`
+			err := db.QueryRow(query, userID, req.ParentID, req.Body).Scan(
+				&comment.ID,
+				&comment.UserID,
+				&comment.ParentID,
+				&comment.Body,
+				&comment.CreatedAt,
+				&comment.UpdatedAt,
+			)
+			if err != nil {
+				return JSONResult{}, Internal("Failed to create comment")
+			}
+
+			return JSONResult{Code: http.StatusCreated, Result: comment}, nil
+		},
+	}
+}
+
+// ListComments returns all comments, oldest first, paginated via
+// ?limit=&cursor= (cursor is the ID of the last comment on the prior page).
+func ListComments(db *sql.DB) http.Handler {
+	return &JSONHandler{
+		DB: db,
+		Process: func(r *http.Request, in interface{}, db *sql.DB) (JSONResult, error) {
+			limit, cursor := parseCommentPage(r)
+
+			rows, err := db.Query(`
+				SELECT id, user_id, parent_id, body, created_at, updated_at
+				FROM comments
+				WHERE id > $1
+				ORDER BY id ASC
+				LIMIT $2
+			`, cursor, limit+1)
+			if err != nil {
+				return JSONResult{}, Internal("Failed to fetch comments")
+			}
+			defer rows.Close()
+
+			return JSONResult{Result: scanCommentPage(rows, limit)}, nil
+		},
+	}
+}
+
+// ListCommentsByUser returns a single user's comments, oldest first,
+// paginated via ?limit=&cursor=.
+func ListCommentsByUser(db *sql.DB) http.Handler {
+	return &JSONHandler{
+		DB: db,
+		Process: func(r *http.Request, in interface{}, db *sql.DB) (JSONResult, error) {
+			userID, err := strconv.Atoi(mux.Vars(r)["id"])
+			if err != nil {
+				return JSONResult{}, BadRequest("Invalid user ID")
+			}
+
+			limit, cursor := parseCommentPage(r)
+
+			rows, err := db.Query(`
+				SELECT id, user_id, parent_id, body, created_at, updated_at
+				FROM comments
+				WHERE user_id = $1 AND id > $2
+				ORDER BY id ASC
+				LIMIT $3
+			`, userID, cursor, limit+1)
+			if err != nil {
+				return JSONResult{}, Internal("Failed to fetch comments")
+			}
+			defer rows.Close()
+
+			return JSONResult{Result: scanCommentPage(rows, limit)}, nil
+		},
+	}
+}
+
+// GetComment returns a single comment by ID.
+func GetComment(db *sql.DB) http.Handler {
+	return &JSONHandler{
+		DB: db,
+		Process: func(r *http.Request, in interface{}, db *sql.DB) (JSONResult, error) {
+			id, err := strconv.Atoi(mux.Vars(r)["id"])
+			if err != nil {
+				return JSONResult{}, BadRequest("Invalid comment ID")
+			}
+
+			comment, err := fetchComment(db, id)
+			if err == sql.ErrNoRows {
+				return JSONResult{}, NotFound("Comment not found")
+			} else if err != nil {
+				return JSONResult{}, Internal("Database error")
+			}
+
+			return JSONResult{Result: comment}, nil
+		},
+	}
+}
+
+// UpdateComment edits a comment's body. Only the author or an admin may do so.
+func UpdateComment(db *sql.DB) http.Handler {
+	return &JSONHandler{
+		DB:    db,
+		Input: func() interface{} { return &models.UpdateCommentRequest{} },
+		Process: func(r *http.Request, in interface{}, db *sql.DB) (JSONResult, error) {
+			req := in.(*models.UpdateCommentRequest)
+
+			id, err := strconv.Atoi(mux.Vars(r)["id"])
+			if err != nil {
+				return JSONResult{}, BadRequest("Invalid comment ID")
+			}
+
+			authUserID, ok := r.Context().Value("userID").(int)
+			if !ok {
+				return JSONResult{}, Unauthorized("Unauthorized")
+			}
+
+			comment, err := fetchComment(db, id)
+			if err == sql.ErrNoRows {
+				return JSONResult{}, NotFound("Comment not found")
+			} else if err != nil {
+				return JSONResult{}, Internal("Database error")
+			}
+
+			if comment.UserID != authUserID && !isAdmin(db, authUserID) {
+				return JSONResult{}, Forbidden("You can only edit your own comments")
+			}
+
+			var updated models.Comment
+			err = db.QueryRow(`
+				UPDATE comments
+				SET body = $1, updated_at = NOW()
+				WHERE id = $2
+				RETURNING id, user_id, parent_id, body, created_at, updated_at
+			`, req.Body, id).Scan(
+				&updated.ID,
+				&updated.UserID,
+				&updated.ParentID,
+				&updated.Body,
+				&updated.CreatedAt,
+				&updated.UpdatedAt,
+			)
+			if err != nil {
+				return JSONResult{}, Internal("Failed to update comment")
+			}
+
+			return JSONResult{Result: updated}, nil
+		},
+	}
+}
+
+// DeleteComment removes a comment. Only the author or an admin may do so.
+func DeleteComment(db *sql.DB) http.Handler {
+	return &JSONHandler{
+		DB: db,
+		Process: func(r *http.Request, in interface{}, db *sql.DB) (JSONResult, error) {
+			id, err := strconv.Atoi(mux.Vars(r)["id"])
+			if err != nil {
+				return JSONResult{}, BadRequest("Invalid comment ID")
+			}
+
+			authUserID, ok := r.Context().Value("userID").(int)
+			if !ok {
+				return JSONResult{}, Unauthorized("Unauthorized")
+			}
+
+			comment, err := fetchComment(db, id)
+			if err == sql.ErrNoRows {
+				return JSONResult{}, NotFound("Comment not found")
+			} else if err != nil {
+				return JSONResult{}, Internal("Database error")
+			}
+
+			if comment.UserID != authUserID && !isAdmin(db, authUserID) {
+				return JSONResult{}, Forbidden("You can only delete your own comments")
+			}
+
+			if _, err := db.Exec(`DELETE FROM comments WHERE id = $1`, id); err != nil {
+				return JSONResult{}, Internal("Failed to delete comment")
+			}
+
+			return JSONResult{Result: map[string]string{"message": "Comment deleted successfully"}}, nil
+		},
+	}
+}
+
+func fetchComment(db *sql.DB, id int) (*models.Comment, error) {
+	var comment models.Comment
+	err := db.QueryRow(`
+		SELECT id, user_id, parent_id, body, created_at, updated_at
+		FROM comments
+		WHERE id = $1
+	`, id).Scan(
+		&comment.ID,
+		&comment.UserID,
+		&comment.ParentID,
+		&comment.Body,
+		&comment.CreatedAt,
+		&comment.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &comment, nil
+}
+
+func parseCommentPage(r *http.Request) (limit, cursor int) {
+	limit = defaultCommentPageSize
+	if v, err := strconv.Atoi(r.URL.Query().Get("limit")); err == nil && v > 0 && v <= maxCommentPageSize {
+		limit = v
+	}
+	cursor, _ = strconv.Atoi(r.URL.Query().Get("cursor"))
+	return limit, cursor
+}
+
+// scanCommentPage drains rows fetched with a LIMIT of limit+1, returning at
+// most limit comments plus the cursor for the next page, if any.
+func scanCommentPage(rows *sql.Rows, limit int) models.CommentList {
+	comments := []models.Comment{}
+	for rows.Next() {
+		var comment models.Comment
+		if err := rows.Scan(
+			&comment.ID,
+			&comment.UserID,
+			&comment.ParentID,
+			&comment.Body,
+			&comment.CreatedAt,
+			&comment.UpdatedAt,
+		); err != nil {
+			continue
+		}
+		comments = append(comments, comment)
+	}
+
+	list := models.CommentList{Comments: comments}
+	if len(comments) > limit {
+		list.Comments = comments[:limit]
+		nextCursor := list.Comments[limit-1].ID
+		list.NextCursor = &nextCursor
+	}
+	return list
+}
@@ -0,0 +1,111 @@
+package handlers
+
+import (
+	"database/sql"
+	"encoding/json"
+	"net/http"
+)
+
+// APIError is a handler error that carries the HTTP status code it should
+// be reported with, so Process functions can return semantic errors
+// instead of writing to http.ResponseWriter directly.
+type APIError struct {
+	Code    int
+	Message string
+}
+
+func (e *APIError) Error() string { return e.Message }
+
+// NotFound builds a 404 APIError.
+func NotFound(message string) error { return &APIError{Code: http.StatusNotFound, Message: message} }
+
+// Forbidden builds a 403 APIError.
+func Forbidden(message string) error { return &APIError{Code: http.StatusForbidden, Message: message} }
+
+// BadRequest builds a 400 APIError.
+func BadRequest(message string) error {
+	return &APIError{Code: http.StatusBadRequest, Message: message}
+}
+
+// Unauthorized builds a 401 APIError.
+func Unauthorized(message string) error {
+	return &APIError{Code: http.StatusUnauthorized, Message: message}
+}
+
+// Conflict builds a 409 APIError.
+func Conflict(message string) error { return &APIError{Code: http.StatusConflict, Message: message} }
+
+// Internal builds a 500 APIError.
+func Internal(message string) error {
+	return &APIError{Code: http.StatusInternalServerError, Message: message}
+}
+
+// JSONResult is what a Process function returns on success: the status
+// code to reply with and the value to encode as the JSON body.
+type JSONResult struct {
+	Code   int
+	Result interface{}
+}
+
+// validator is implemented by request types that need validation beyond
+// what JSON decoding itself enforces. JSONHandler runs it automatically
+// after decoding, so Process functions don't each need their own call.
+type validator interface {
+	Validate() error
+}
+
+// JSONHandler centralizes the request/response boilerplate shared by every
+// JSON handler in this package: decoding the request body (when Input is
+// set), validating it (when it implements validator), running Process,
+// and mapping the outcome - or an APIError - to a response. It removes
+// the repeated
+// w.Header().Set(...); w.WriteHeader(...); json.NewEncoder(w).Encode(...)
+// that used to appear in every handler.
+type JSONHandler struct {
+	DB      *sql.DB
+	Input   func() interface{}
+	Process func(r *http.Request, in interface{}, db *sql.DB) (JSONResult, error)
+}
+
+func (h *JSONHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	var in interface{}
+	if h.Input != nil {
+		in = h.Input()
+		if r.ContentLength != 0 {
+			if err := json.NewDecoder(r.Body).Decode(in); err != nil {
+				writeJSONError(w, BadRequest("Invalid request body"))
+				return
+			}
+		}
+		if v, ok := in.(validator); ok {
+			if err := v.Validate(); err != nil {
+				writeJSONError(w, BadRequest(err.Error()))
+				return
+			}
+		}
+	}
+
+	result, err := h.Process(r, in, h.DB)
+	if err != nil {
+		writeJSONError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if result.Code != 0 {
+		w.WriteHeader(result.Code)
+	}
+	json.NewEncoder(w).Encode(result.Result)
+}
+
+func writeJSONError(w http.ResponseWriter, err error) {
+	code := http.StatusInternalServerError
+	message := "Internal server error"
+	if apiErr, ok := err.(*APIError); ok {
+		code = apiErr.Code
+		message = apiErr.Message
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	json.NewEncoder(w).Encode(ErrorResponse{Error: message})
+}
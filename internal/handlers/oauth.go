@@ -0,0 +1,218 @@
+package handlers
+
+import (
+	"crypto/rand"
+	"database/sql"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/lib/pq"
+	"github.com/yourusername/go-user-api/internal/auth"
+	"github.com/yourusername/go-user-api/internal/models"
+)
+
+const oauthStateCookie = "oauth_state"
+
+// OAuthLogin redirects the browser to the given provider's consent screen.
+func OAuthLogin(providers map[string]auth.OAuthProvider) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		provider, ok := providers[mux.Vars(r)["provider"]]
+		if !ok {
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusNotFound)
+			json.NewEncoder(w).Encode(ErrorResponse{Error: "Unknown OAuth provider"})
+			return
+		}
+
+		state, err := randomState()
+		if err != nil {
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusInternalServerError)
+			json.NewEncoder(w).Encode(ErrorResponse{Error: "Failed to start OAuth flow"})
+			return
+		}
+
+		http.SetCookie(w, &http.Cookie{
+			Name:     oauthStateCookie,
+			Value:    state,
+			Path:     "/",
+			Expires:  time.Now().Add(10 * time.Minute),
+			HttpOnly: true,
+		})
+
+		http.Redirect(w, r, provider.AuthURL(state), http.StatusFound)
+	}
+}
+
+// OAuthCallback exchanges the authorization code for a token, resolves the
+// provider's user info to a local account (provisioning or linking one on
+// first login), and issues the same JWT local login would.
+func OAuthCallback(db *sql.DB, providers map[string]auth.OAuthProvider) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		provider, ok := providers[mux.Vars(r)["provider"]]
+		if !ok {
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusNotFound)
+			json.NewEncoder(w).Encode(ErrorResponse{Error: "Unknown OAuth provider"})
+			return
+		}
+
+		cookie, err := r.Cookie(oauthStateCookie)
+		if err != nil || r.URL.Query().Get("state") != cookie.Value {
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusBadRequest)
+			json.NewEncoder(w).Encode(ErrorResponse{Error: "Invalid OAuth state"})
+			return
+		}
+
+		code := r.URL.Query().Get("code")
+		if code == "" {
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusBadRequest)
+			json.NewEncoder(w).Encode(ErrorResponse{Error: "Missing authorization code"})
+			return
+		}
+
+		token, err := provider.Exchange(r.Context(), code)
+		if err != nil {
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusBadGateway)
+			json.NewEncoder(w).Encode(ErrorResponse{Error: "Failed to exchange authorization code"})
+			return
+		}
+
+		info, err := provider.UserInfo(r.Context(), token)
+		if err != nil {
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusBadGateway)
+			json.NewEncoder(w).Encode(ErrorResponse{Error: "Failed to fetch provider profile"})
+			return
+		}
+
+		user, err := findOrCreateOAuthUser(db, provider.Name(), info)
+		if err != nil {
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusInternalServerError)
+			json.NewEncoder(w).Encode(ErrorResponse{Error: "Failed to provision account"})
+			return
+		}
+
+		accessToken, refreshToken, _, err := issueTokenPair(db, user)
+		if err != nil {
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusInternalServerError)
+			json.NewEncoder(w).Encode(ErrorResponse{Error: "Failed to generate token"})
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(models.LoginResponse{
+			AccessToken:  accessToken,
+			RefreshToken: refreshToken,
+			User:         *user,
+		})
+	}
+}
+
+// findOrCreateOAuthUser links an existing oauth_identities row to its user,
+// or provisions a new local user (auth_type "oauth") and links it on first
+// callback for a given provider+subject pair.
+func findOrCreateOAuthUser(db *sql.DB, provider string, info *auth.UserInfo) (*models.User, error) {
+	if info.Subject == "" || info.Email == "" {
+		return nil, fmt.Errorf("oauth userinfo missing subject or email")
+	}
+
+	var user models.User
+
+	err := db.QueryRow(`
+		SELECT u.id, u.email, u.username, u.auth_type, u.role, u.scopes, u.first_name, u.last_name, u.created_at, u.updated_at
+		FROM oauth_identities oi
+		JOIN users u ON u.id = oi.user_id
+		WHERE oi.provider = $1 AND oi.subject = $2
+	`, provider, info.Subject).Scan(
+		&user.ID,
+		&user.Email,
+		&user.Username,
+		&user.AuthType,
+		&user.Role,
+		pq.Array(&user.Scopes),
+		&user.FirstName,
+		&user.LastName,
+		&user.CreatedAt,
+		&user.UpdatedAt,
+	)
+	if err == nil {
+		return &user, nil
+	}
+	if err != sql.ErrNoRows {
+		return nil, err
+	}
+
+	tx, err := db.Begin()
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	err = tx.QueryRow(`
+		SELECT id, email, username, auth_type, role, scopes, first_name, last_name, created_at, updated_at
+		FROM users WHERE email = $1
+	`, info.Email).Scan(
+		&user.ID,
+		&user.Email,
+		&user.Username,
+		&user.AuthType,
+		&user.Role,
+		pq.Array(&user.Scopes),
+		&user.FirstName,
+		&user.LastName,
+		&user.CreatedAt,
+		&user.UpdatedAt,
+	)
+	if err == sql.ErrNoRows {
+		err = tx.QueryRow(`
+			INSERT INTO users (email, username, password_hash, auth_type, first_name, last_name)
+			VALUES ($1, $2, '', 'oauth', $3, '')
+			RETURNING id, email, username, auth_type, role, scopes, first_name, last_name, created_at, updated_at
+		`, info.Email, info.Email, info.Name).Scan(
+			&user.ID,
+			&user.Email,
+			&user.Username,
+			&user.AuthType,
+			&user.Role,
+			pq.Array(&user.Scopes),
+			&user.FirstName,
+			&user.LastName,
+			&user.CreatedAt,
+			&user.UpdatedAt,
+		)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := tx.Exec(`
+		INSERT INTO oauth_identities (user_id, provider, subject)
+		VALUES ($1, $2, $3)
+	`, user.ID, provider, info.Subject); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	return &user, nil
+}
+
+func randomState() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
@@ -0,0 +1,135 @@
+package handlers
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+	"time"
+
+	"github.com/yourusername/go-user-api/internal/mailer"
+	"github.com/yourusername/go-user-api/internal/models"
+	"github.com/yourusername/go-user-api/internal/utils"
+)
+
+// ForgotPassword issues a single-use password reset token and emails it to
+// the given address. For a request that carries an email it returns 200,
+// whether or not the email belongs to an account, to avoid leaking which
+// emails are registered; a missing email gets 400 and a database error 500.
+func ForgotPassword(db *sql.DB, m mailer.Mailer) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req models.ForgotPasswordRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.Email == "" {
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusBadRequest)
+			json.NewEncoder(w).Encode(ErrorResponse{Error: "Email is required"})
+			return
+		}
+
+		var userID int
+		err := db.QueryRow(`SELECT id FROM users WHERE email = $1`, req.Email).Scan(&userID)
+		if err == nil {
+			if sendErr := issuePasswordReset(db, m, userID, req.Email); sendErr != nil {
+				// Log and swallow: a mailer failure must not distinguish a
+				// registered email from an unregistered one in the response.
+				log.Printf("forgot password: failed to send reset email: %v", sendErr)
+			}
+		} else if err != sql.ErrNoRows {
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusInternalServerError)
+			json.NewEncoder(w).Encode(ErrorResponse{Error: "Database error"})
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]string{"message": "If that email exists, a reset link has been sent"})
+	}
+}
+
+func issuePasswordReset(db *sql.DB, m mailer.Mailer, userID int, email string) error {
+	token, err := utils.GenerateResetToken()
+	if err != nil {
+		return err
+	}
+
+	_, err = db.Exec(`
+		INSERT INTO password_resets (user_id, token_hash, expires_at)
+		VALUES ($1, $2, $3)
+	`, userID, utils.HashResetToken(token), time.Now().Add(utils.PasswordResetTokenTTL))
+	if err != nil {
+		return err
+	}
+
+	resetURL := os.Getenv("PASSWORD_RESET_URL")
+	body := fmt.Sprintf("Use this token to reset your password: %s", token)
+	if resetURL != "" {
+		body = fmt.Sprintf("Reset your password: %s?token=%s", resetURL, token)
+	}
+
+	return m.Send(email, "Reset your password", body)
+}
+
+// ResetPassword consumes a password reset token, sets a new password, and
+// revokes all of the user's outstanding refresh tokens.
+func ResetPassword(db *sql.DB) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req models.ResetPasswordRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.Token == "" {
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusBadRequest)
+			json.NewEncoder(w).Encode(ErrorResponse{Error: "Invalid request body"})
+			return
+		}
+
+		if err := models.ValidatePassword(req.NewPassword); err != nil {
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusBadRequest)
+			json.NewEncoder(w).Encode(ErrorResponse{Error: err.Error()})
+			return
+		}
+
+		var (
+			resetID int
+			userID  int
+		)
+		err := db.QueryRow(`
+			SELECT id, user_id FROM password_resets
+			WHERE token_hash = $1 AND used_at IS NULL AND expires_at > NOW()
+		`, utils.HashResetToken(req.Token)).Scan(&resetID, &userID)
+
+		if err == sql.ErrNoRows {
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusBadRequest)
+			json.NewEncoder(w).Encode(ErrorResponse{Error: "Invalid or expired reset token"})
+			return
+		} else if err != nil {
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusInternalServerError)
+			json.NewEncoder(w).Encode(ErrorResponse{Error: "Database error"})
+			return
+		}
+
+		hashedPassword, err := utils.HashPassword(req.NewPassword)
+		if err != nil {
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusInternalServerError)
+			json.NewEncoder(w).Encode(ErrorResponse{Error: "Failed to process password"})
+			return
+		}
+
+		if _, err := db.Exec(`UPDATE users SET password_hash = $1, updated_at = NOW() WHERE id = $2`, hashedPassword, userID); err != nil {
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusInternalServerError)
+			json.NewEncoder(w).Encode(ErrorResponse{Error: "Failed to update password"})
+			return
+		}
+
+		db.Exec(`UPDATE password_resets SET used_at = NOW() WHERE id = $1`, resetID)
+		revokeAllRefreshTokens(db, userID)
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]string{"message": "Password reset successfully"})
+	}
+}
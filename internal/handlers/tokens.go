@@ -0,0 +1,169 @@
+package handlers
+
+import (
+	"database/sql"
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/lib/pq"
+	"github.com/yourusername/go-user-api/internal/models"
+	"github.com/yourusername/go-user-api/internal/utils"
+)
+
+// issueTokenPair generates a new access+refresh token pair for a user and
+// persists the refresh token's hash so it can later be rotated or revoked.
+// refreshID is the new refresh_tokens row's ID, handed back so callers
+// rotating an old token can record the replaced_by link.
+func issueTokenPair(db *sql.DB, user *models.User) (accessToken, refreshToken string, refreshID int, err error) {
+	accessToken, err = utils.GenerateAccessToken(user.ID, user.Role, user.Scopes)
+	if err != nil {
+		return "", "", 0, err
+	}
+
+	refreshToken, err = utils.GenerateRefreshToken()
+	if err != nil {
+		return "", "", 0, err
+	}
+
+	err = db.QueryRow(`
+		INSERT INTO refresh_tokens (user_id, token_hash, expires_at)
+		VALUES ($1, $2, $3)
+		RETURNING id
+	`, user.ID, utils.HashRefreshToken(refreshToken), time.Now().Add(utils.RefreshTokenTTL)).Scan(&refreshID)
+	if err != nil {
+		return "", "", 0, err
+	}
+
+	return accessToken, refreshToken, refreshID, nil
+}
+
+// Refresh verifies a refresh token, rotates it, and issues a new
+// access+refresh pair. Presenting a refresh token that has already been
+// rotated or revoked is treated as token theft: every outstanding refresh
+// token of that user is revoked, not only the token's own rotation chain.
+func Refresh(db *sql.DB) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req models.RefreshRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.RefreshToken == "" {
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusBadRequest)
+			json.NewEncoder(w).Encode(ErrorResponse{Error: "Refresh token is required"})
+			return
+		}
+
+		tokenHash := utils.HashRefreshToken(req.RefreshToken)
+
+		var (
+			id        int
+			userID    int
+			expiresAt time.Time
+			revokedAt sql.NullTime
+		)
+		err := db.QueryRow(`
+			SELECT id, user_id, expires_at, revoked_at
+			FROM refresh_tokens
+			WHERE token_hash = $1
+		`, tokenHash).Scan(&id, &userID, &expiresAt, &revokedAt)
+
+		if err == sql.ErrNoRows {
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusUnauthorized)
+			json.NewEncoder(w).Encode(ErrorResponse{Error: "Invalid refresh token"})
+			return
+		} else if err != nil {
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusInternalServerError)
+			json.NewEncoder(w).Encode(ErrorResponse{Error: "Database error"})
+			return
+		}
+
+		if revokedAt.Valid {
+			// This token was already rotated or revoked; someone is
+			// replaying an old refresh token, so kill every session for
+			// this user rather than just this one token.
+			revokeAllRefreshTokens(db, userID)
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusUnauthorized)
+			json.NewEncoder(w).Encode(ErrorResponse{Error: "Refresh token reuse detected; all sessions revoked"})
+			return
+		}
+
+		if time.Now().After(expiresAt) {
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusUnauthorized)
+			json.NewEncoder(w).Encode(ErrorResponse{Error: "Refresh token expired"})
+			return
+		}
+
+		var user models.User
+		err = db.QueryRow(`
+			SELECT id, email, username, auth_type, role, scopes, first_name, last_name, created_at, updated_at
+			FROM users WHERE id = $1
+		`, userID).Scan(
+			&user.ID,
+			&user.Email,
+			&user.Username,
+			&user.AuthType,
+			&user.Role,
+			pq.Array(&user.Scopes),
+			&user.FirstName,
+			&user.LastName,
+			&user.CreatedAt,
+			&user.UpdatedAt,
+		)
+		if err != nil {
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusInternalServerError)
+			json.NewEncoder(w).Encode(ErrorResponse{Error: "Database error"})
+			return
+		}
+
+		accessToken, refreshToken, newID, err := issueTokenPair(db, &user)
+		if err != nil {
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusInternalServerError)
+			json.NewEncoder(w).Encode(ErrorResponse{Error: "Failed to issue tokens"})
+			return
+		}
+
+		db.Exec(`UPDATE refresh_tokens SET revoked_at = NOW(), replaced_by = $1 WHERE id = $2`, newID, id)
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(models.LoginResponse{
+			AccessToken:  accessToken,
+			RefreshToken: refreshToken,
+			User:         user,
+		})
+	}
+}
+
+// Logout revokes the presented refresh token, ending that session.
+func Logout(db *sql.DB) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req models.RefreshRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.RefreshToken == "" {
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusBadRequest)
+			json.NewEncoder(w).Encode(ErrorResponse{Error: "Refresh token is required"})
+			return
+		}
+
+		db.Exec(`
+			UPDATE refresh_tokens SET revoked_at = NOW()
+			WHERE token_hash = $1 AND revoked_at IS NULL
+		`, utils.HashRefreshToken(req.RefreshToken))
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]string{"message": "Logged out successfully"})
+	}
+}
+
+// revokeAllRefreshTokens revokes every outstanding refresh token for a
+// user, e.g. on detected token reuse or a password reset.
+func revokeAllRefreshTokens(db *sql.DB, userID int) {
+	db.Exec(`
+		UPDATE refresh_tokens SET revoked_at = NOW()
+		WHERE user_id = $1 AND revoked_at IS NULL
+	`, userID)
+}
@@ -2,240 +2,224 @@ package handlers
 
 import (
 	"database/sql"
-	"encoding/json"
 	"net/http"
 	"strconv"
 
 	"github.com/gorilla/mux"
+	"github.com/lib/pq"
 	"github.com/yourusername/go-user-api/internal/models"
 )
 
 // GetUsers returns all users
-func GetUsers(db *sql.DB) http.HandlerFunc {
-	return func(w http.ResponseWriter, r *http.Request) {
-		query := `
-			SELECT id, email, username, first_name, last_name, created_at, updated_at
-			FROM users
-			ORDER BY created_at DESC
-		`
-		rows, err := db.Query(query)
-		if err != nil {
-			w.Header().Set("Content-Type", "application/json")
-			w.WriteHeader(http.StatusInternalServerError)
-			json.NewEncoder(w).Encode(ErrorResponse{Error: "Failed to fetch users"})
-			return
-		}
-		defer rows.Close()
-
-		users := []models.User{}
-		for rows.Next() {
+func GetUsers(db *sql.DB) http.Handler {
+	return &JSONHandler{
+		DB: db,
+		Process: func(r *http.Request, in interface{}, db *sql.DB) (JSONResult, error) {
+			query := `
+				SELECT id, email, username, auth_type, role, scopes, first_name, last_name, created_at, updated_at
+				FROM users
+				ORDER BY created_at DESC
+			`
+			rows, err := db.Query(query)
+			if err != nil {
+				return JSONResult{}, Internal("Failed to fetch users")
+			}
+			defer rows.Close()
+
+			users := []models.User{}
+			for rows.Next() {
+				var user models.User
+				err := rows.Scan(
+					&user.ID,
+					&user.Email,
+					&user.Username,
+					&user.AuthType,
+					&user.Role,
+					pq.Array(&user.Scopes),
+					&user.FirstName,
+					&user.LastName,
+					&user.CreatedAt,
+					&user.UpdatedAt,
+				)
+				if err != nil {
+					continue
+				}
+				users = append(users, user)
+			}
+
+			return JSONResult{Result: users}, nil
+		},
+	}
+}
+
+// GetUser returns a single user by ID
+func GetUser(db *sql.DB) http.Handler {
+	return &JSONHandler{
+		DB: db,
+		Process: func(r *http.Request, in interface{}, db *sql.DB) (JSONResult, error) {
+			id, err := strconv.Atoi(mux.Vars(r)["id"])
+			if err != nil {
+				return JSONResult{}, BadRequest("Invalid user ID")
+			}
+
 			var user models.User
-			err := rows.Scan(
+			query := `
+				SELECT id, email, username, auth_type, role, scopes, first_name, last_name, created_at, updated_at
+				FROM users
+				WHERE id = $1
+			`
+			err = db.QueryRow(query, id).Scan(
 				&user.ID,
 				&user.Email,
 				&user.Username,
+				&user.AuthType,
+				&user.Role,
+				pq.Array(&user.Scopes),
 				&user.FirstName,
 				&user.LastName,
 				&user.CreatedAt,
 				&user.UpdatedAt,
 			)
-			if err != nil {
-				continue
+
+			if err == sql.ErrNoRows {
+				return JSONResult{}, NotFound("User not found")
+			} else if err != nil {
+				return JSONResult{}, Internal("Database error")
 			}
-			users = append(users, user)
-		}
 
-		w.Header().Set("Content-Type", "application/json")
-		json.NewEncoder(w).Encode(users)
-	}
-}
-
-// GetUser returns a single user by ID
-func GetUser(db *sql.DB) http.HandlerFunc {
-	return func(w http.ResponseWriter, r *http.Request) {
-		vars := mux.Vars(r)
-		id, err := strconv.Atoi(vars["id"])
-		if err != nil {
-			w.Header().Set("Content-Type", "application/json")
-			w.WriteHeader(http.StatusBadRequest)
-			json.NewEncoder(w).Encode(ErrorResponse{Error: "Invalid user ID"})
-			return
-		}
-
-		var user models.User
-		query := `
-			SELECT id, email, username, first_name, last_name, created_at, updated_at
-			FROM users
-			WHERE id = $1
-		`
-		err = db.QueryRow(query, id).Scan(
-			&user.ID,
-			&user.Email,
-			&user.Username,
-			&user.FirstName,
-			&user.LastName,
-			&user.CreatedAt,
-			&user.UpdatedAt,
-		)
-
-		if err == sql.ErrNoRows {
-			w.Header().Set("Content-Type", "application/json")
-			w.WriteHeader(http.StatusNotFound)
-			json.NewEncoder(w).Encode(ErrorResponse{Error: "User not found"})
-			return
-		} else if err != nil {
-			w.Header().Set("Content-Type", "application/json")
-			w.WriteHeader(http.StatusInternalServerError)
-			json.NewEncoder(w).Encode(ErrorResponse{Error: "Database error"})
-			return
-		}
-
-		w.Header().Set("Content-Type", "application/json")
-		json.NewEncoder(w).Encode(user)
+			return JSONResult{Result: user}, nil
+		},
 	}
 }
 
 // GetCurrentUser returns the authenticated user's information
-func GetCurrentUser(db *sql.DB) http.HandlerFunc {
-	return func(w http.ResponseWriter, r *http.Request) {
-		// Get user ID from context (set by auth middleware)
-		userID, ok := r.Context().Value("userID").(int)
-		if !ok {
-			w.Header().Set("Content-Type", "application/json")
-			w.WriteHeader(http.StatusUnauthorized)
-			json.NewEncoder(w).Encode(ErrorResponse{Error: "Unauthorized"})
-			return
-		}
-
-		var user models.User
-		query := `
-			SELECT id, email, username, first_name, last_name, created_at, updated_at
-			FROM users
-			WHERE id = $1
-		`
-		err := db.QueryRow(query, userID).Scan(
-			&user.ID,
-			&user.Email,
-			&user.Username,
-			&user.FirstName,
-			&user.LastName,
-			&user.CreatedAt,
-			&user.UpdatedAt,
-		)
-
-		if err != nil {
-			w.Header().Set("Content-Type", "application/json")
-			w.WriteHeader(http.StatusInternalServerError)
-			json.NewEncoder(w).Encode(ErrorResponse{Error: "Failed to fetch user"})
-			return
-		}
-
-		w.Header().Set("Content-Type", "application/json")
-		json.NewEncoder(w).Encode(user)
+func GetCurrentUser(db *sql.DB) http.Handler {
+	return &JSONHandler{
+		DB: db,
+		Process: func(r *http.Request, in interface{}, db *sql.DB) (JSONResult, error) {
+			// Get user ID from context (set by auth middleware)
+			userID, ok := r.Context().Value("userID").(int)
+			if !ok {
+				return JSONResult{}, Unauthorized("Unauthorized")
+			}
+
+			var user models.User
+			query := `
+				SELECT id, email, username, auth_type, role, scopes, first_name, last_name, created_at, updated_at
+				FROM users
+				WHERE id = $1
+			`
+			err := db.QueryRow(query, userID).Scan(
+				&user.ID,
+				&user.Email,
+				&user.Username,
+				&user.AuthType,
+				&user.Role,
+				pq.Array(&user.Scopes),
+				&user.FirstName,
+				&user.LastName,
+				&user.CreatedAt,
+				&user.UpdatedAt,
+			)
+			if err != nil {
+				return JSONResult{}, Internal("Failed to fetch user")
+			}
+
+			return JSONResult{Result: user}, nil
+		},
 	}
 }
 
 // UpdateUser updates a user's information
-func UpdateUser(db *sql.DB) http.HandlerFunc {
-	return func(w http.ResponseWriter, r *http.Request) {
-		vars := mux.Vars(r)
-		id, err := strconv.Atoi(vars["id"])
-		if err != nil {
-			w.Header().Set("Content-Type", "application/json")
-			w.WriteHeader(http.StatusBadRequest)
-			json.NewEncoder(w).Encode(ErrorResponse{Error: "Invalid user ID"})
-			return
-		}
-
-		// Get authenticated user ID
-		authUserID, ok := r.Context().Value("userID").(int)
-		if !ok || authUserID != id {
-			w.Header().Set("Content-Type", "application/json")
-			w.WriteHeader(http.StatusForbidden)
-			json.NewEncoder(w).Encode(ErrorResponse{Error: "You can only update your own profile"})
-			return
-		}
-
-		var req models.UpdateUserRequest
-		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-			w.Header().Set("Content-Type", "application/json")
-			w.WriteHeader(http.StatusBadRequest)
-			json.NewEncoder(w).Encode(ErrorResponse{Error: "Invalid request body"})
-			return
-		}
-
-		// Build update query dynamically based on provided fields
-		query := `
-			UPDATE users
-			SET first_name = COALESCE(NULLIF($1, ''), first_name),
-			    last_name = COALESCE(NULLIF($2, ''), last_name),
-			    updated_at = NOW()
-			WHERE id = $3
-			RETURNING id, email, username, first_name, last_name, created_at, updated_at
-		`
-
-		var user models.User
-		err = db.QueryRow(query, req.FirstName, req.LastName, id).Scan(
-			&user.ID,
-			&user.Email,
-			&user.Username,
-			&user.FirstName,
-			&user.LastName,
-			&user.CreatedAt,
-			&user.UpdatedAt,
-		)
-
-		if err != nil {
-			w.Header().Set("Content-Type", "application/json")
-			w.WriteHeader(http.StatusInternalServerError)
-			json.NewEncoder(w).Encode(ErrorResponse{Error: "Failed to update user"})
-			return
-		}
-
-		w.Header().Set("Content-Type", "application/json")
-		json.NewEncoder(w).Encode(user)
+func UpdateUser(db *sql.DB) http.Handler {
+	return &JSONHandler{
+		DB:    db,
+		Input: func() interface{} { return &models.UpdateUserRequest{} },
+		Process: func(r *http.Request, in interface{}, db *sql.DB) (JSONResult, error) {
+			req := in.(*models.UpdateUserRequest)
+
+			id, err := strconv.Atoi(mux.Vars(r)["id"])
+			if err != nil {
+				return JSONResult{}, BadRequest("Invalid user ID")
+			}
+
+			// Get authenticated user ID; admins may update any profile
+			authUserID, ok := r.Context().Value("userID").(int)
+			if !ok || (authUserID != id && !isAdmin(db, authUserID)) {
+				return JSONResult{}, Forbidden("You can only update your own profile")
+			}
+
+			// Build update query dynamically based on provided fields
+			query := `
+				UPDATE users
+				SET first_name = COALESCE(NULLIF($1, ''), first_name),
+				    last_name = COALESCE(NULLIF($2, ''), last_name),
+				    updated_at = NOW()
+				WHERE id = $3
+				RETURNING id, email, username, auth_type, role, scopes, first_name, last_name, created_at, updated_at
+			`
+
+			var user models.User
+			err = db.QueryRow(query, req.FirstName, req.LastName, id).Scan(
+				&user.ID,
+				&user.Email,
+				&user.Username,
+				&user.AuthType,
+				&user.Role,
+				pq.Array(&user.Scopes),
+				&user.FirstName,
+				&user.LastName,
+				&user.CreatedAt,
+				&user.UpdatedAt,
+			)
+			if err != nil {
+				return JSONResult{}, Internal("Failed to update user")
+			}
+
+			return JSONResult{Result: user}, nil
+		},
 	}
 }
 
 // DeleteUser deletes a user
-func DeleteUser(db *sql.DB) http.HandlerFunc {
-	return func(w http.ResponseWriter, r *http.Request) {
-		vars := mux.Vars(r)
-		id, err := strconv.Atoi(vars["id"])
-		if err != nil {
-			w.Header().Set("Content-Type", "application/json")
-			w.WriteHeader(http.StatusBadRequest)
-			json.NewEncoder(w).Encode(ErrorResponse{Error: "Invalid user ID"})
-			return
-		}
-
-		// Get authenticated user ID
-		authUserID, ok := r.Context().Value("userID").(int)
-		if !ok || authUserID != id {
-			w.Header().Set("Content-Type", "application/json")
-			w.WriteHeader(http.StatusForbidden)
-			json.NewEncoder(w).Encode(ErrorResponse{Error: "You can only delete your own account"})
-			return
-		}
-
-		query := `DELETE FROM users WHERE id = $1`
-		result, err := db.Exec(query, id)
-		if err != nil {
-			w.Header().Set("Content-Type", "application/json")
-			w.WriteHeader(http.StatusInternalServerError)
-			json.NewEncoder(w).Encode(ErrorResponse{Error: "Failed to delete user"})
-			return
-		}
-
-		rowsAffected, _ := result.RowsAffected()
-		if rowsAffected == 0 {
-			w.Header().Set("Content-Type", "application/json")
-			w.WriteHeader(http.StatusNotFound)
-			json.NewEncoder(w).Encode(ErrorResponse{Error: "User not found"})
-			return
-		}
-
-		w.Header().Set("Content-Type", "application/json")
-		json.NewEncoder(w).Encode(map[string]string{"message": "User deleted successfully"})
+func DeleteUser(db *sql.DB) http.Handler {
+	return &JSONHandler{
+		DB: db,
+		Process: func(r *http.Request, in interface{}, db *sql.DB) (JSONResult, error) {
+			id, err := strconv.Atoi(mux.Vars(r)["id"])
+			if err != nil {
+				return JSONResult{}, BadRequest("Invalid user ID")
+			}
+
+			// Get authenticated user ID; admins may delete any account
+			authUserID, ok := r.Context().Value("userID").(int)
+			if !ok || (authUserID != id && !isAdmin(db, authUserID)) {
+				return JSONResult{}, Forbidden("You can only delete your own account")
+			}
+
+			query := `DELETE FROM users WHERE id = $1`
+			result, err := db.Exec(query, id)
+			if err != nil {
+				return JSONResult{}, Internal("Failed to delete user")
+			}
+
+			rowsAffected, _ := result.RowsAffected()
+			if rowsAffected == 0 {
+				return JSONResult{}, NotFound("User not found")
+			}
+
+			return JSONResult{Result: map[string]string{"message": "User deleted successfully"}}, nil
+		},
+	}
+}
+
+// isAdmin reports whether the given user currently holds the admin role.
+// Checked against the database rather than the caller's JWT so a demotion
+// takes effect immediately instead of waiting for the token to expire.
+func isAdmin(db *sql.DB, userID int) bool {
+	var role string
+	if err := db.QueryRow(`SELECT role FROM users WHERE id = $1`, userID).Scan(&role); err != nil {
+		return false
 	}
+	return role == "admin"
 }
@@ -0,0 +1,12 @@
+package mailer
+
+import "log"
+
+// LogMailer writes emails to the server log instead of sending them. Used
+// in development when no SMTP relay is configured.
+type LogMailer struct{}
+
+func (m *LogMailer) Send(to, subject, body string) error {
+	log.Printf("mailer: would send email to=%s subject=%q body=%q", to, subject, body)
+	return nil
+}
@@ -0,0 +1,26 @@
+package mailer
+
+import "os"
+
+// Mailer sends a single plain-text email. Implementations back onto SMTP in
+// production and onto the log in development.
+type Mailer interface {
+	Send(to, subject, body string) error
+}
+
+// FromEnv builds a Mailer from environment variables: an SMTPMailer if
+// SMTP_HOST is configured, otherwise a LogMailer for local development.
+func FromEnv() Mailer {
+	host := os.Getenv("SMTP_HOST")
+	if host == "" {
+		return &LogMailer{}
+	}
+
+	return NewSMTPMailer(
+		host,
+		os.Getenv("SMTP_PORT"),
+		os.Getenv("SMTP_USER"),
+		os.Getenv("SMTP_PASSWORD"),
+		os.Getenv("SMTP_FROM"),
+	)
+}
@@ -0,0 +1,34 @@
+package mailer
+
+import (
+	"fmt"
+	"net/smtp"
+)
+
+// SMTPMailer sends email through an SMTP relay.
+type SMTPMailer struct {
+	host string
+	port string
+	user string
+	pass string
+	from string
+}
+
+// NewSMTPMailer builds an SMTPMailer from connection details, typically
+// sourced from SMTP_HOST / SMTP_PORT / SMTP_USER / SMTP_PASSWORD /
+// SMTP_FROM environment variables.
+func NewSMTPMailer(host, port, user, pass, from string) *SMTPMailer {
+	return &SMTPMailer{host: host, port: port, user: user, pass: pass, from: from}
+}
+
+func (m *SMTPMailer) Send(to, subject, body string) error {
+	addr := fmt.Sprintf("%s:%s", m.host, m.port)
+	msg := fmt.Sprintf("From: %s\r\nTo: %s\r\nSubject: %s\r\n\r\n%s\r\n", m.from, to, subject, body)
+
+	var auth smtp.Auth
+	if m.user != "" {
+		auth = smtp.PlainAuth("", m.user, m.pass, m.host)
+	}
+
+	return smtp.SendMail(addr, auth, m.from, []string{to}, []byte(msg))
+}
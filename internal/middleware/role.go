@@ -0,0 +1,75 @@
+package middleware
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"github.com/yourusername/go-user-api/internal/utils"
+)
+
+type roleErrorResponse struct {
+	Error string `json:"error"`
+}
+
+func bearerClaims(r *http.Request) (*utils.Claims, error) {
+	header := r.Header.Get("Authorization")
+	parts := strings.SplitN(header, " ", 2)
+	if len(parts) != 2 || !strings.EqualFold(parts[0], "Bearer") {
+		return nil, http.ErrNoCookie
+	}
+	return utils.ParseClaims(parts[1])
+}
+
+// RequireRole restricts a route to callers whose JWT role claim is one of
+// the given roles. It must be chained after Auth, which already validates
+// the token; RequireRole re-parses it to inspect the role claim.
+func RequireRole(roles ...string) func(http.Handler) http.Handler {
+	allowed := make(map[string]bool, len(roles))
+	for _, role := range roles {
+		allowed[role] = true
+	}
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			claims, err := bearerClaims(r)
+			if err != nil || !allowed[claims.Role] {
+				w.Header().Set("Content-Type", "application/json")
+				w.WriteHeader(http.StatusForbidden)
+				json.NewEncoder(w).Encode(roleErrorResponse{Error: "Insufficient role"})
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// RequireScope restricts a route to callers whose JWT scopes claim
+// contains all of the given scopes.
+func RequireScope(scopes ...string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			claims, err := bearerClaims(r)
+			if err != nil || !hasAllScopes(claims.Scopes, scopes) {
+				w.Header().Set("Content-Type", "application/json")
+				w.WriteHeader(http.StatusForbidden)
+				json.NewEncoder(w).Encode(roleErrorResponse{Error: "Insufficient scope"})
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+func hasAllScopes(granted, required []string) bool {
+	have := make(map[string]bool, len(granted))
+	for _, s := range granted {
+		have[s] = true
+	}
+	for _, s := range required {
+		if !have[s] {
+			return false
+		}
+	}
+	return true
+}
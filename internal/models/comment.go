@@ -0,0 +1,52 @@
+package models
+
+import (
+	"errors"
+	"time"
+)
+
+type Comment struct {
+	ID        int       `json:"id"`
+	UserID    int       `json:"user_id"`
+	ParentID  *int      `json:"parent_id,omitempty"`
+	Body      string    `json:"body"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+type CreateCommentRequest struct {
+	ParentID *int   `json:"parent_id,omitempty"`
+	Body     string `json:"body"`
+}
+
+type UpdateCommentRequest struct {
+	Body string `json:"body"`
+}
+
+// CommentList is the response for the paginated comment listing endpoints.
+type CommentList struct {
+	Comments   []Comment `json:"comments"`
+	NextCursor *int      `json:"next_cursor,omitempty"`
+}
+
+// Validate checks if comment data is valid
+func (r *CreateCommentRequest) Validate() error {
+	if r.Body == "" {
+		return errors.New("body is required")
+	}
+	if len(r.Body) > 10000 {
+		return errors.New("body must be at most 10000 characters")
+	}
+	return nil
+}
+
+// Validate checks if comment data is valid
+func (r *UpdateCommentRequest) Validate() error {
+	if r.Body == "" {
+		return errors.New("body is required")
+	}
+	if len(r.Body) > 10000 {
+		return errors.New("body must be at most 10000 characters")
+	}
+	return nil
+}
@@ -0,0 +1,12 @@
+package models
+
+// ForgotPasswordRequest is the body of POST /api/auth/password/forgot.
+type ForgotPasswordRequest struct {
+	Email string `json:"email"`
+}
+
+// ResetPasswordRequest is the body of POST /api/auth/password/reset.
+type ResetPasswordRequest struct {
+	Token       string `json:"token"`
+	NewPassword string `json:"new_password"`
+}
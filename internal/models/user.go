@@ -10,13 +10,31 @@ type User struct {
 	ID           int       `json:"id"`
 	Email        string    `json:"email"`
 	Username     string    `json:"username"`
-	PasswordHash string    `json:"-"` // Never send password hash to client
+	PasswordHash string    `json:"-"`         // Never send password hash to client
+	AuthType     string    `json:"auth_type"` // "local" or "oauth"
+	Role         string    `json:"role"`      // "user" or "admin"
+	Scopes       []string  `json:"scopes"`
 	FirstName    string    `json:"first_name,omitempty"`
 	LastName     string    `json:"last_name,omitempty"`
 	CreatedAt    time.Time `json:"created_at"`
 	UpdatedAt    time.Time `json:"updated_at"`
 }
 
+// UpdateScopesRequest is the body of PATCH /api/users/{id}/scopes.
+type UpdateScopesRequest struct {
+	Scopes []string `json:"scopes"`
+}
+
+// OAuthIdentity links a third-party identity (provider + subject) to a
+// local user account.
+type OAuthIdentity struct {
+	ID        int       `json:"id"`
+	UserID    int       `json:"user_id"`
+	Provider  string    `json:"provider"`
+	Subject   string    `json:"subject"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
 type RegisterRequest struct {
 	Email     string `json:"email"`
 	Username  string `json:"username"`
@@ -31,8 +49,15 @@ type LoginRequest struct {
 }
 
 type LoginResponse struct {
-	Token string `json:"token"`
-	User  User   `json:"user"`
+	AccessToken  string `json:"access_token"`
+	RefreshToken string `json:"refresh_token"`
+	User         User   `json:"user"`
+}
+
+// RefreshRequest is the body of POST /api/auth/refresh and
+// POST /api/auth/logout.
+type RefreshRequest struct {
+	RefreshToken string `json:"refresh_token"`
 }
 
 type UpdateUserRequest struct {
@@ -56,10 +81,16 @@ func (r *RegisterRequest) Validate() error {
 	if len(r.Username) < 3 {
 		return errors.New("username must be at least 3 characters")
 	}
-	if r.Password == "" {
+	return ValidatePassword(r.Password)
+}
+
+// ValidatePassword applies the same password rules used at registration,
+// so a password reset can't set a weaker password than sign-up would allow.
+func ValidatePassword(password string) error {
+	if password == "" {
 		return errors.New("password is required")
 	}
-	if len(r.Password) < 6 {
+	if len(password) < 6 {
 		return errors.New("password must be at least 6 characters")
 	}
 	return nil
@@ -9,22 +9,33 @@ import (
 )
 
 type Claims struct {
-	UserID int `json:"user_id"`
+	UserID int      `json:"user_id"`
+	Role   string   `json:"role"`
+	Scopes []string `json:"scopes,omitempty"`
 	jwt.RegisteredClaims
 }
 
-// GenerateToken creates a new JWT token for a user
-func GenerateToken(userID int) (string, error) {
+// AccessTokenTTL is the lifetime of a JWT access token. It is intentionally
+// short since, unlike a refresh token, an access token cannot be revoked
+// server-side and can only be invalidated by waiting for it to expire.
+const AccessTokenTTL = 15 * time.Minute
+
+// GenerateAccessToken creates a new short-lived JWT access token for a
+// user, embedding their role and scopes so downstream middleware can
+// authorize without a DB round trip.
+func GenerateAccessToken(userID int, role string, scopes []string) (string, error) {
 	secretKey := os.Getenv("JWT_SECRET")
 	if secretKey == "" {
 		return "", errors.New("JWT_SECRET not set in environment")
 	}
 
-	// Create claims with user ID and expiration
+	// Create claims with user ID, role, scopes and expiration
 	claims := Claims{
 		UserID: userID,
+		Role:   role,
+		Scopes: scopes,
 		RegisteredClaims: jwt.RegisteredClaims{
-			ExpiresAt: jwt.NewNumericDate(time.Now().Add(24 * time.Hour)),
+			ExpiresAt: jwt.NewNumericDate(time.Now().Add(AccessTokenTTL)),
 			IssuedAt:  jwt.NewNumericDate(time.Now()),
 		},
 	}
@@ -68,3 +79,29 @@ func ValidateToken(tokenString string) (int, error) {
 
 	return 0, errors.New("invalid token")
 }
+
+// ParseClaims parses and validates a JWT token, returning its full claim
+// set so callers can inspect role/scopes in addition to the user ID.
+func ParseClaims(tokenString string) (*Claims, error) {
+	secretKey := os.Getenv("JWT_SECRET")
+	if secretKey == "" {
+		return nil, errors.New("JWT_SECRET not set in environment")
+	}
+
+	token, err := jwt.ParseWithClaims(tokenString, &Claims{}, func(token *jwt.Token) (interface{}, error) {
+		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
+			return nil, errors.New("invalid signing method")
+		}
+		return []byte(secretKey), nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	claims, ok := token.Claims.(*Claims)
+	if !ok || !token.Valid {
+		return nil, errors.New("invalid token")
+	}
+
+	return claims, nil
+}
@@ -0,0 +1,29 @@
+package utils
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"time"
+)
+
+// PasswordResetTokenTTL is how long a password reset token stays valid.
+const PasswordResetTokenTTL = time.Hour
+
+// GenerateResetToken returns a new single-use, 256-bit random password
+// reset token. Only its hash (see HashResetToken) should ever be persisted.
+func GenerateResetToken() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// HashResetToken returns the SHA-256 hash of a password reset token, as
+// stored in the password_resets table.
+func HashResetToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,30 @@
+package utils
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"time"
+)
+
+// RefreshTokenTTL is the lifetime of a refresh token.
+const RefreshTokenTTL = 30 * 24 * time.Hour
+
+// GenerateRefreshToken returns a new opaque, 256-bit random refresh token.
+// Only its hash (see HashRefreshToken) should ever be persisted.
+func GenerateRefreshToken() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// HashRefreshToken returns the SHA-256 hash of a refresh token, as stored
+// in the refresh_tokens table. Hashing means a leaked database dump can't
+// be used to forge sessions.
+func HashRefreshToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}